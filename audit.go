@@ -0,0 +1,107 @@
+package sanctum
+
+/*
+#include "sanctum.h"
+#include <stdlib.h>
+
+extern void goAuditEvent(uintptr_t handle, char *event_json, size_t len);
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+// auditSubscription receives events pushed by goAuditEvent from the vault's
+// dedicated audit thread and forwards them to the caller's channel.
+type auditSubscription struct {
+	mu     sync.Mutex
+	events chan AuditEvent
+	closed bool
+}
+
+func (s *auditSubscription) deliver(event AuditEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	// Drop the event rather than block the vault's audit thread if the
+	// caller isn't keeping up.
+	select {
+	case s.events <- event:
+	default:
+	}
+}
+
+func (s *auditSubscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
+
+//export goAuditEvent
+func goAuditEvent(handle C.uintptr_t, eventJSON *C.char, length C.size_t) {
+	sub, ok := cgo.Handle(handle).Value().(*auditSubscription)
+	if !ok {
+		return
+	}
+
+	raw := C.GoBytes(unsafe.Pointer(eventJSON), C.int(length))
+	var event AuditEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return
+	}
+	sub.deliver(event)
+}
+
+// TailAudit subscribes to a live stream of audit events matching filter.
+// The C library invokes goAuditEvent from a dedicated vault thread for
+// each event; the returned channel is closed once ctx is done or the
+// subscription is torn down. This replaces polling AuditLog snapshots for
+// real-time SIEM integration (tail -f semantics, file sinks, forwarding
+// to syslog/Splunk/OpenTelemetry).
+func (v *Vault) TailAudit(ctx context.Context, filter AuditFilter) (<-chan AuditEvent, error) {
+	if v.ptr == nil {
+		return nil, errors.New("sanctum: vault is closed")
+	}
+
+	filterJSON, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("sanctum: marshal filter: %w", err)
+	}
+	cFilter := C.CString(string(filterJSON))
+	defer C.free(unsafe.Pointer(cFilter))
+
+	sub := &auditSubscription{events: make(chan AuditEvent, 16)}
+	handle := cgo.NewHandle(sub)
+
+	var subID C.uint64_t
+	rc := C.sanctum_vault_audit_subscribe(v.ptr, cFilter, C.audit_callback_t(C.goAuditEvent), C.uintptr_t(handle), &subID)
+	if err := resultToError(rc); err != nil {
+		handle.Delete()
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		sub.close()
+		// Ask the vault's audit thread to stop invoking the callback for
+		// this subscription and wait for it to confirm before freeing
+		// handle: a callback that fires on an already-deleted handle
+		// panics, and the audit thread runs concurrently with us.
+		C.sanctum_vault_audit_unsubscribe(v.ptr, subID)
+		handle.Delete()
+	}()
+
+	return sub.events, nil
+}
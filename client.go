@@ -4,47 +4,337 @@ package sanctum
 import (
 	"context"
 	"crypto/ed25519"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"net"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// ClientConfig configures a Client's transport. The zero value dials
+// nothing; use NewClientWithConfig, or the NewClient/NewTCPClient
+// shorthands for the common unix-socket and plaintext-TCP cases.
+type ClientConfig struct {
+	// Network and Address are passed to Dialer, e.g. ("unix", "/run/sanctum.sock")
+	// or ("tcp", "127.0.0.1:9090").
+	Network string
+	Address string
+
+	// Dialer establishes the connection. Defaults to net.Dialer.DialContext,
+	// overridable for tests (net.Pipe) or alternate transports (SSH tunnels).
+	Dialer func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// TLSConfig, if set, wraps the dialed connection with tls.Client and
+	// performs the handshake within HandshakeTimeout.
+	TLSConfig *tls.Config
+
+	// HandshakeTimeout bounds the TLS handshake. Defaults to 10s.
+	HandshakeTimeout time.Duration
+
+	// MaxFrameSize caps a single frame read from the server, in bytes.
+	// Defaults to defaultMaxFrameSize (16 MiB).
+	MaxFrameSize uint32
+
+	// AuthFunc, if set, is called to (re-)authenticate immediately after
+	// every connect, including automatic reconnects after a recoverable
+	// transport error.
+	AuthFunc func(ctx context.Context, c *Client) error
+}
+
 // Client communicates with a SanctumAI vault over Unix socket or TCP.
+//
+// A reader goroutine owns all reads from the current connection and
+// demultiplexes them: replies (ID != 0) are routed to the caller awaiting
+// that request ID, and server-pushed notifications (ID == 0) are routed
+// to the subscription they belong to. This lets multiple calls, and
+// streaming subscriptions like TailAudit, share one connection. A
+// separate supervisor goroutine watches for that reader to report a
+// transport error; on a recoverable one, it redials using the stored
+// config and re-authenticates via AuthFunc, starting a fresh reader for
+// the new connection, before resuming. AuthFunc always runs concurrently
+// with a reader for the connection it's authenticating, so it's free to
+// issue calls of its own (e.g. Client.Authenticate) without deadlocking.
 type Client struct {
+	cfg ClientConfig
+
+	connMu sync.RWMutex
 	conn   net.Conn
-	mu     sync.Mutex
-	nextID atomic.Uint64
+
+	writeMu sync.Mutex
+	nextID  atomic.Uint64
+
+	pendingMu sync.Mutex
+	pending   map[uint64]chan callResult
+
+	subsMu sync.Mutex
+	subs   map[string]chan json.RawMessage
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	closeErr  error
 }
 
 // NewClient connects to a SanctumAI vault via Unix socket.
 func NewClient(socketPath string) (*Client, error) {
-	conn, err := net.Dial("unix", socketPath)
-	if err != nil {
-		return nil, fmt.Errorf("connect unix %s: %w", socketPath, err)
-	}
-	c := &Client{conn: conn}
-	c.nextID.Store(1)
-	return c, nil
+	return NewClientWithConfig(ClientConfig{Network: "unix", Address: socketPath})
 }
 
 // NewTCPClient connects to a SanctumAI vault via TCP.
 func NewTCPClient(addr string) (*Client, error) {
-	conn, err := net.Dial("tcp", addr)
-	if err != nil {
-		return nil, fmt.Errorf("connect tcp %s: %w", addr, err)
+	return NewClientWithConfig(ClientConfig{Network: "tcp", Address: addr})
+}
+
+// NewClientWithConfig connects to a SanctumAI vault using cfg, which gives
+// control over the dialer, TLS/mTLS, frame size limits, and automatic
+// re-authentication on reconnect.
+func NewClientWithConfig(cfg ClientConfig) (*Client, error) {
+	if cfg.HandshakeTimeout <= 0 {
+		cfg.HandshakeTimeout = 10 * time.Second
+	}
+	if cfg.MaxFrameSize == 0 {
+		cfg.MaxFrameSize = defaultMaxFrameSize
+	}
+	if cfg.Dialer == nil {
+		var d net.Dialer
+		cfg.Dialer = d.DialContext
+	}
+
+	c := &Client{
+		cfg:     cfg,
+		pending: make(map[uint64]chan callResult),
+		subs:    make(map[string]chan json.RawMessage),
+		closed:  make(chan struct{}),
 	}
-	c := &Client{conn: conn}
 	c.nextID.Store(1)
+
+	conn, errCh, err := c.connectAndAuth(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("initial authentication: %w", err)
+	}
+
+	go c.supervise(conn, errCh)
 	return c, nil
 }
 
-// Close closes the connection to the vault.
+// connectAndAuth dials a fresh connection, installs it as c.conn, and
+// starts a reader goroutine for it before calling cfg.AuthFunc (if set) on
+// the caller's own goroutine. Starting the reader first means AuthFunc's
+// own calls always have something reading their replies, whether this is
+// the client's very first connection or a reconnect. The returned channel
+// reports that reader's eventual transport error; the caller owns closing
+// conn if it gives up on it.
+func (c *Client) connectAndAuth(ctx context.Context) (net.Conn, chan error, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+
+	errCh := make(chan error, 1)
+	go c.readLoop(conn, errCh)
+
+	if c.cfg.AuthFunc != nil {
+		if err := c.cfg.AuthFunc(ctx, c); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
+
+	return conn, errCh, nil
+}
+
+// dial establishes and, if configured, TLS-wraps one connection per
+// cfg.Network/cfg.Address. It does not touch c.conn or start a reader;
+// callers install the result themselves.
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	conn, err := c.cfg.Dialer(ctx, c.cfg.Network, c.cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("connect %s %s: %w", c.cfg.Network, c.cfg.Address, err)
+	}
+
+	if c.cfg.TLSConfig != nil {
+		tlsConn := tls.Client(conn, c.cfg.TLSConfig)
+		hctx, cancel := context.WithTimeout(ctx, c.cfg.HandshakeTimeout)
+		defer cancel()
+		if err := tlsConn.HandshakeContext(hctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("tls handshake: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	return conn, nil
+}
+
+func (c *Client) getConn() net.Conn {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.conn
+}
+
+// Close closes the connection to the vault and stops the reader and
+// supervisor goroutines, failing any calls and subscriptions still in
+// flight.
 func (c *Client) Close() error {
-	return c.conn.Close()
+	err := c.getConn().Close()
+	c.shutdown(fmt.Errorf("sanctum: client closed"))
+	return err
+}
+
+// readLoop is the sole reader of conn for as long as conn is the client's
+// current connection. It routes each frame to the pending call or
+// subscription it belongs to, and reports the error that ends it (Close,
+// a dropped connection, or a protocol violation) on errCh.
+func (c *Client) readLoop(conn net.Conn, errCh chan<- error) {
+	for {
+		resp, err := readFrameLimit(conn, c.cfg.MaxFrameSize)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		if resp.ID == 0 && resp.Method != "" {
+			c.routeNotification(resp.Params)
+			continue
+		}
+
+		c.pendingMu.Lock()
+		ch, ok := c.pending[resp.ID]
+		if ok {
+			delete(c.pending, resp.ID)
+		}
+		c.pendingMu.Unlock()
+		if ok {
+			ch <- callResult{resp: resp}
+		}
+	}
+}
+
+// supervise watches the reader for the given connection and, on a
+// recoverable transport error, redials and re-authenticates before
+// starting a fresh reader and continuing to watch. It runs until a
+// terminal error ends the connection or the client is closed, at which
+// point it calls shutdown.
+func (c *Client) supervise(conn net.Conn, errCh chan error) {
+	for {
+		err := <-errCh
+		if !IsRecoverable(classifyCallError(err)) {
+			c.shutdown(classifyCallError(err))
+			return
+		}
+
+		// Every call waiting on the dead connection needs to fail now;
+		// it can't know a reconnect is in progress.
+		c.failPending(err)
+
+		// Subscriptions were registered on the dead connection and get no
+		// events on a new one, so their channels are closed as well.
+		c.subsMu.Lock()
+		for _, ch := range c.subs {
+			close(ch)
+		}
+		c.subs = make(map[string]chan json.RawMessage)
+		c.subsMu.Unlock()
+
+		newConn, newErrCh, ok := c.reconnect()
+		if !ok {
+			c.shutdown(err)
+			return
+		}
+		conn, errCh = newConn, newErrCh
+	}
+}
+
+// reconnect retries connectAndAuth with exponential backoff until it
+// succeeds or the client is closed. Each attempt starts its own reader
+// before calling AuthFunc, so AuthFunc's calls are always serviced by a
+// goroutine distinct from the one driving the backoff loop.
+func (c *Client) reconnect() (net.Conn, chan error, bool) {
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+	for {
+		select {
+		case <-c.closed:
+			return nil, nil, false
+		default:
+		}
+
+		conn, errCh, err := c.connectAndAuth(context.Background())
+		if err == nil {
+			return conn, errCh, true
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-c.closed:
+			return nil, nil, false
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// failPending fails every call currently awaiting a reply with err,
+// without tearing down the client itself (a reconnect may still succeed).
+func (c *Client) failPending(err error) {
+	c.pendingMu.Lock()
+	pending := c.pending
+	c.pending = make(map[uint64]chan callResult)
+	c.pendingMu.Unlock()
+
+	for _, ch := range pending {
+		ch <- callResult{err: err}
+	}
+}
+
+// shutdown fails every pending call and closes every subscription with
+// err, then closes c.closed so future calls fail fast. It also closes the
+// current connection, if any, so a terminal error (one supervise won't
+// reconnect from) doesn't leak the socket.
+func (c *Client) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		c.closeErr = err
+		c.getConn().Close()
+
+		c.pendingMu.Lock()
+		pending := c.pending
+		c.pending = make(map[uint64]chan callResult)
+		c.pendingMu.Unlock()
+		for _, ch := range pending {
+			close(ch)
+		}
+
+		// Close every subscription channel under subsMu, the same lock
+		// routeNotification sends under: that makes the close and any
+		// in-flight send mutually exclusive, so routeNotification can
+		// never land a send on a channel shutdown just closed.
+		c.subsMu.Lock()
+		for _, ch := range c.subs {
+			close(ch)
+		}
+		c.subs = make(map[string]chan json.RawMessage)
+		c.subsMu.Unlock()
+
+		close(c.closed)
+	})
+}
+
+// callResult is delivered on a pending call's channel: either the frame
+// the dispatcher read for it, or a transport error from failPending if a
+// reconnect happened (or failed) before a reply arrived.
+type callResult struct {
+	resp *RpcResponse
+	err  error
 }
 
 func (c *Client) call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
@@ -53,43 +343,171 @@ func (c *Client) call(ctx context.Context, method string, params interface{}) (j
 		return nil, fmt.Errorf("marshal params: %w", err)
 	}
 
+	id := c.nextID.Add(1) - 1
 	req := &RpcRequest{
-		ID:     c.nextID.Add(1) - 1,
+		ID:     id,
 		Method: method,
 		Params: paramsBytes,
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	replyCh := make(chan callResult, 1)
+	c.pendingMu.Lock()
+	c.pending[id] = replyCh
+	c.pendingMu.Unlock()
 
-	// Set deadline from context
-	if deadline, ok := ctx.Deadline(); ok {
-		if err := c.conn.SetDeadline(deadline); err != nil {
-			return nil, err
+	c.writeMu.Lock()
+	err = writeFrame(c.getConn(), req)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return nil, classifyCallError(err)
+	}
+
+	select {
+	case result, ok := <-replyCh:
+		if !ok {
+			return nil, classifyCallError(c.closeErr)
 		}
-		defer c.conn.SetDeadline(time.Time{}) //nolint:errcheck
+		if result.err != nil {
+			return nil, classifyCallError(result.err)
+		}
+		return parseCallResult(result.resp)
+	case <-c.closed:
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return nil, classifyCallError(c.closeErr)
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return nil, ctx.Err()
 	}
+}
 
-	if err := writeFrame(c.conn, req); err != nil {
-		return nil, err
+// notificationEnvelope is the params payload of a server-pushed
+// Notification frame, tagging which subscription it belongs to.
+type notificationEnvelope struct {
+	SubscriptionID string          `json:"subscription_id"`
+	Event          json.RawMessage `json:"event"`
+}
+
+// routeNotification delivers a Notification frame's event to the
+// subscription it names, dropping it if the subscription is unknown (e.g.
+// already unsubscribed) or the subscriber isn't keeping up. The lookup and
+// send happen under subsMu, the same lock shutdown closes subscription
+// channels under, so a notification can never land on a channel shutdown
+// has already closed.
+func (c *Client) routeNotification(params json.RawMessage) {
+	var env notificationEnvelope
+	if err := json.Unmarshal(params, &env); err != nil {
+		return
 	}
 
-	resp, err := readFrame(c.conn)
-	if err != nil {
-		return nil, err
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	ch, ok := c.subs[env.SubscriptionID]
+	if !ok {
+		return
 	}
 
+	select {
+	case ch <- env.Event:
+	default:
+	}
+}
+
+func parseCallResult(resp *RpcResponse) (json.RawMessage, error) {
 	if resp.Error != nil {
 		var vaultErr VaultError
 		if err := json.Unmarshal(resp.Error, &vaultErr); err != nil {
-			return nil, &ProtocolError{Message: "failed to parse error response"}
+			return nil, classifyCallError(&ProtocolError{Message: "failed to parse error response"})
 		}
-		return nil, &vaultErr
+		return nil, classifyCallError(&vaultErr)
 	}
-
 	return resp.Result, nil
 }
 
+// classifyCallError wraps a failure from call in a RecoverableError. I/O
+// errors (timeouts, closed connections, resets, EOF mid-frame) and
+// transient VaultError codes are recoverable; everything else, including
+// malformed frames, is terminal.
+func classifyCallError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var vaultErr *VaultError
+	if errors.As(err, &vaultErr) {
+		switch vaultErr.Code {
+		case ErrVaultLocked, ErrRateLimited, ErrInternal:
+			return &RecoverableError{Err: err, Recoverable: true}
+		default:
+			return &RecoverableError{Err: err, Recoverable: false}
+		}
+	}
+
+	var protoErr *ProtocolError
+	if errors.As(err, &protoErr) {
+		return &RecoverableError{Err: err, Recoverable: false}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &RecoverableError{Err: err, Recoverable: true}
+	}
+
+	// readFrameLimit wraps these with %w: a plain io.EOF on a clean close
+	// between frames, io.ErrUnexpectedEOF on a close mid-frame. Neither
+	// satisfies net.Error, but both mean the same thing a reset or closed
+	// socket does: the vault went away and reconnecting may recover.
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, net.ErrClosed) {
+		return &RecoverableError{Err: err, Recoverable: true}
+	}
+
+	return &RecoverableError{Err: err, Recoverable: false}
+}
+
+// RetryPolicy configures CallWithRetry's exponential backoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// CallWithRetry invokes method like call, but retries errors classified as
+// recoverable using exponential backoff with full jitter, up to
+// policy.MaxAttempts. It stops early, without retrying, on terminal errors
+// or if ctx is done.
+func (c *Client) CallWithRetry(ctx context.Context, method string, params interface{}, policy RetryPolicy) (json.RawMessage, error) {
+	backoff := policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		result, err := c.call(ctx, method, params)
+		if err == nil {
+			return result, nil
+		}
+		if !IsRecoverable(err) || attempt >= policy.MaxAttempts {
+			return nil, err
+		}
+
+		sleep := time.Duration(rand.Int63n(int64(backoff) + 1))
+		select {
+		case <-time.After(sleep):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
 // Authenticate performs Ed25519 challenge-response authentication.
 func (c *Client) Authenticate(ctx context.Context, agentName string, privateKey ed25519.PrivateKey) error {
 	// Step 1: Request challenge
@@ -164,6 +582,146 @@ func (c *Client) ReleaseLease(ctx context.Context, leaseID string) error {
 	return err
 }
 
+// RenewLease renews a credential lease, requesting the given increment in
+// seconds (0 lets the server choose its own default increment). The server
+// may return a different TTL than requested, which callers should respect.
+func (c *Client) RenewLease(ctx context.Context, leaseID string, increment int) (*Credential, error) {
+	result, err := c.call(ctx, "lease.renew", map[string]interface{}{
+		"lease_id":  leaseID,
+		"increment": increment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var cred Credential
+	if err := json.Unmarshal(result, &cred); err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+	return &cred, nil
+}
+
+// Wrap issues a single-use wrapped token for the credential at path, valid
+// for wrapTTL. The token can be handed off to a subprocess or sibling
+// agent, which exchanges it exactly once via Unwrap, letting a privileged
+// orchestrator broker credentials to ephemeral workers without either
+// party seeing the real lease ID.
+func (c *Client) Wrap(ctx context.Context, path string, wrapTTL time.Duration) (*WrappedToken, error) {
+	result, err := c.call(ctx, "credential.wrap", map[string]interface{}{
+		"path":     path,
+		"wrap_ttl": uint64(wrapTTL.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var wrapped WrappedToken
+	if err := json.Unmarshal(result, &wrapped); err != nil {
+		return nil, fmt.Errorf("parse wrapped token: %w", err)
+	}
+	return &wrapped, nil
+}
+
+// Unwrap exchanges a wrapped token for the credential it holds. Tokens are
+// single-use: a second call with the same token fails.
+func (c *Client) Unwrap(ctx context.Context, token string) (*Credential, error) {
+	result, err := c.call(ctx, "credential.unwrap", map[string]string{"token": token})
+	if err != nil {
+		return nil, err
+	}
+	var cred Credential
+	if err := json.Unmarshal(result, &cred); err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+	return &cred, nil
+}
+
+// Capabilities returns the set of allowed actions (e.g. "retrieve", "use",
+// "delete", "list", "wrap", "renew") per path for the authenticated agent,
+// evaluated against each path's stored policy. Callers can use this to
+// pre-flight what they are allowed to do, or to discover which operations
+// Use supports for a given path, without trial-and-error.
+func (c *Client) Capabilities(ctx context.Context, paths []string) (map[string][]string, error) {
+	result, err := c.call(ctx, "credential.capabilities", map[string]interface{}{
+		"paths": paths,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var caps map[string][]string
+	if err := json.Unmarshal(result, &caps); err != nil {
+		return nil, fmt.Errorf("parse capabilities: %w", err)
+	}
+	return caps, nil
+}
+
+// subscribeResult is returned by audit.subscribe.
+type subscribeResult struct {
+	SubscriptionID string `json:"subscription_id"`
+}
+
+// TailAudit subscribes to a live stream of audit events matching filter,
+// returning a channel that receives each event as the server pushes it.
+// The channel is closed when ctx is done, the connection is lost, or
+// Client.Close is called; callers do not need to drain it after that
+// point. This replaces polling Vault.AuditLog snapshots for real-time SIEM
+// integration.
+func (c *Client) TailAudit(ctx context.Context, filter AuditFilter) (<-chan AuditEvent, error) {
+	result, err := c.call(ctx, "audit.subscribe", filter)
+	if err != nil {
+		return nil, err
+	}
+	var sub subscribeResult
+	if err := json.Unmarshal(result, &sub); err != nil {
+		return nil, fmt.Errorf("parse subscribe result: %w", err)
+	}
+
+	rawCh := make(chan json.RawMessage, 16)
+	c.subsMu.Lock()
+	c.subs[sub.SubscriptionID] = rawCh
+	c.subsMu.Unlock()
+
+	events := make(chan AuditEvent, 16)
+	go func() {
+		defer close(events)
+		defer func() {
+			c.subsMu.Lock()
+			delete(c.subs, sub.SubscriptionID)
+			c.subsMu.Unlock()
+
+			// Best-effort: tell the server to stop pushing to a
+			// subscription we're abandoning. Use a short timeout of our
+			// own rather than the caller's ctx, which is already done by
+			// the time we get here.
+			unsubCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			c.call(unsubCtx, "audit.unsubscribe", map[string]string{"subscription_id": sub.SubscriptionID})
+		}()
+
+		for {
+			select {
+			case raw, ok := <-rawCh:
+				if !ok {
+					return
+				}
+				var event AuditEvent
+				if err := json.Unmarshal(raw, &event); err != nil {
+					continue
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			case <-c.closed:
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
 // Use performs a use-not-retrieve operation on a credential.
 func (c *Client) Use(ctx context.Context, path, operation string, params map[string]interface{}) (*UseResult, error) {
 	result, err := c.call(ctx, "credential.use", map[string]interface{}{
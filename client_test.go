@@ -2,11 +2,33 @@ package sanctum
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync"
 	"testing"
+	"time"
 )
 
+// writeTestFrame writes v as a length-prefixed JSON frame, mirroring the
+// wire format writeFrame/readFrameLimit use. writeFrame itself is typed to
+// *RpcRequest (the client->server direction), so tests that play the
+// server side of a net.Pipe conversation use this instead.
+func writeTestFrame(w io.Writer, v interface{}) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(payload))); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
 func TestWriteReadFrame(t *testing.T) {
 	params, _ := json.Marshal(map[string]string{"key": "value"})
 	req := &RpcRequest{
@@ -67,3 +89,209 @@ func TestProtocolErrorFormat(t *testing.T) {
 		t.Errorf("unexpected: %s", err.Error())
 	}
 }
+
+func TestClassifyCallError(t *testing.T) {
+	cases := []struct {
+		name        string
+		err         error
+		recoverable bool
+	}{
+		{"clean EOF", io.EOF, true},
+		{"EOF mid-frame", fmt.Errorf("read payload: %w", io.ErrUnexpectedEOF), true},
+		{"closed pipe", io.ErrClosedPipe, true},
+		{"closed connection", net.ErrClosed, true},
+		{"vault locked", &VaultError{Code: ErrVaultLocked}, true},
+		{"rate limited", &VaultError{Code: ErrRateLimited}, true},
+		{"access denied", &VaultError{Code: "ACCESS_DENIED"}, false},
+		{"protocol error", &ProtocolError{Message: "frame too large"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsRecoverable(classifyCallError(tc.err)); got != tc.recoverable {
+				t.Errorf("classifyCallError(%v) recoverable = %v, want %v", tc.err, got, tc.recoverable)
+			}
+		})
+	}
+}
+
+// TestCallWithRetryRecoversFromTransientError drives a Client against one
+// side of a net.Pipe, with a fake server on the other side that fails the
+// first call with a recoverable VaultError before succeeding, to confirm
+// CallWithRetry retries instead of giving up.
+func TestCallWithRetryRecoversFromTransientError(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go func() {
+		for attempt := 0; ; attempt++ {
+			req, err := readFrameLimit(serverConn, defaultMaxFrameSize)
+			if err != nil {
+				return
+			}
+			resp := &RpcResponse{ID: req.ID}
+			if attempt == 0 {
+				errBytes, _ := json.Marshal(&VaultError{Code: ErrInternal, Message: "overloaded"})
+				resp.Error = errBytes
+			} else {
+				resp.Result = json.RawMessage(`{"ok":true}`)
+			}
+			if writeTestFrame(serverConn, resp) != nil {
+				return
+			}
+		}
+	}()
+
+	c := &Client{
+		cfg:     ClientConfig{MaxFrameSize: defaultMaxFrameSize},
+		conn:    clientConn,
+		pending: make(map[uint64]chan callResult),
+		subs:    make(map[string]chan json.RawMessage),
+		closed:  make(chan struct{}),
+	}
+	c.nextID.Store(1)
+	go c.readLoop(clientConn, make(chan error, 1))
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}
+	result, err := c.CallWithRetry(context.Background(), "credential.retrieve", nil, policy)
+	if err != nil {
+		t.Fatalf("CallWithRetry: %v", err)
+	}
+	if string(result) != `{"ok":true}` {
+		t.Errorf("got %s, want {\"ok\":true}", result)
+	}
+}
+
+// TestNewClientWithConfigRunsAuthFuncConcurrentlyWithReader guards against
+// the deadlock where AuthFunc, which issues its own call and blocks
+// waiting for a reply, used to run before anything was reading the
+// connection.
+func TestNewClientWithConfigRunsAuthFuncConcurrentlyWithReader(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+
+	go func() {
+		for {
+			req, err := readFrameLimit(serverConn, defaultMaxFrameSize)
+			if err != nil {
+				return
+			}
+			resp := &RpcResponse{ID: req.ID, Result: json.RawMessage(`{"authenticated":true}`)}
+			if writeTestFrame(serverConn, resp) != nil {
+				return
+			}
+		}
+	}()
+
+	var authCalled bool
+	cfg := ClientConfig{
+		Dialer: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return clientConn, nil
+		},
+		AuthFunc: func(ctx context.Context, c *Client) error {
+			authCalled = true
+			_, err := c.call(ctx, "auth.ping", nil)
+			return err
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		c, err := NewClientWithConfig(cfg)
+		if c != nil {
+			c.Close()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("NewClientWithConfig: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("NewClientWithConfig deadlocked waiting on AuthFunc")
+	}
+	if !authCalled {
+		t.Fatal("AuthFunc was not called")
+	}
+}
+
+// TestClientReconnectsAndReauthenticates simulates a vault restart (the
+// server closing the connection right after authenticating) and confirms
+// the client redials and runs AuthFunc again on the new connection,
+// rather than deadlocking the way reconnect used to.
+func TestClientReconnectsAndReauthenticates(t *testing.T) {
+	clientConn1, serverConn1 := net.Pipe()
+	clientConn2, serverConn2 := net.Pipe()
+
+	serve := func(serverConn net.Conn, closeAfterFirst bool) {
+		n := 0
+		for {
+			req, err := readFrameLimit(serverConn, defaultMaxFrameSize)
+			if err != nil {
+				return
+			}
+			n++
+			if writeTestFrame(serverConn, &RpcResponse{ID: req.ID, Result: json.RawMessage(`{"authenticated":true}`)}) != nil {
+				return
+			}
+			if closeAfterFirst && n == 1 {
+				serverConn.Close()
+				return
+			}
+		}
+	}
+	go serve(serverConn1, true)
+	go serve(serverConn2, false)
+
+	var mu sync.Mutex
+	dials, auths := 0, 0
+	secondDial := make(chan struct{})
+	reauthed := make(chan struct{})
+	cfg := ClientConfig{
+		Dialer: func(ctx context.Context, network, address string) (net.Conn, error) {
+			mu.Lock()
+			dials++
+			n := dials
+			mu.Unlock()
+			switch n {
+			case 1:
+				return clientConn1, nil
+			case 2:
+				close(secondDial)
+				return clientConn2, nil
+			default:
+				return nil, fmt.Errorf("no more test connections")
+			}
+		},
+		AuthFunc: func(ctx context.Context, c *Client) error {
+			_, err := c.call(ctx, "auth.ping", nil)
+			if err == nil {
+				mu.Lock()
+				auths++
+				n := auths
+				mu.Unlock()
+				if n == 2 {
+					close(reauthed)
+				}
+			}
+			return err
+		},
+	}
+
+	c, err := NewClientWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewClientWithConfig: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case <-secondDial:
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not reconnect and re-authenticate after the first connection closed")
+	}
+	select {
+	case <-reauthed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("AuthFunc did not complete on the new connection")
+	}
+}
@@ -59,3 +59,63 @@ func lastErrorMessage() string {
 	}
 	return C.GoString(p)
 }
+
+// VaultErrorCode identifies the category of a VaultError reported by the
+// RPC server, distinct from the FFI result codes above.
+type VaultErrorCode string
+
+const (
+	ErrVaultLocked VaultErrorCode = "VAULT_LOCKED"
+	ErrRateLimited VaultErrorCode = "RATE_LIMITED"
+	ErrInternal    VaultErrorCode = "INTERNAL"
+)
+
+// VaultError is returned when the vault RPC server reports a failure for a
+// call.
+type VaultError struct {
+	Code    VaultErrorCode `json:"code"`
+	Message string         `json:"message"`
+	Detail  string         `json:"detail,omitempty"`
+}
+
+func (e *VaultError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("[%s] %s — %s", e.Code, e.Message, e.Detail)
+}
+
+// ProtocolError indicates a framing or transport-level protocol violation,
+// such as an oversized or malformed frame.
+type ProtocolError struct {
+	Message string
+}
+
+func (e *ProtocolError) Error() string {
+	return "protocol error: " + e.Message
+}
+
+// RecoverableError wraps an RPC failure with a hint as to whether the call
+// that produced it is worth retrying.
+type RecoverableError struct {
+	Err         error
+	Recoverable bool
+}
+
+func (e *RecoverableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecoverableError) Unwrap() error {
+	return e.Err
+}
+
+// IsRecoverable reports whether err, or any error in its chain, has been
+// classified as recoverable by Client.call.
+func IsRecoverable(err error) bool {
+	var re *RecoverableError
+	if errors.As(err, &re) {
+		return re.Recoverable
+	}
+	return false
+}
@@ -7,7 +7,9 @@ import (
 	"io"
 )
 
-const maxFrameSize = 16 * 1024 * 1024 // 16 MB
+// defaultMaxFrameSize is the frame size cap used by readFrame and by
+// Client when ClientConfig.MaxFrameSize is left at its zero value.
+const defaultMaxFrameSize = 16 * 1024 * 1024 // 16 MB
 
 // writeFrame writes a length-prefixed JSON-RPC request.
 func writeFrame(w io.Writer, req *RpcRequest) error {
@@ -25,13 +27,20 @@ func writeFrame(w io.Writer, req *RpcRequest) error {
 	return nil
 }
 
-// readFrame reads a length-prefixed JSON-RPC response.
+// readFrame reads a length-prefixed JSON-RPC response, capping it at
+// defaultMaxFrameSize. Use readFrameLimit for a custom cap.
 func readFrame(r io.Reader) (*RpcResponse, error) {
+	return readFrameLimit(r, defaultMaxFrameSize)
+}
+
+// readFrameLimit reads a length-prefixed JSON-RPC response, rejecting any
+// frame larger than maxSize bytes.
+func readFrameLimit(r io.Reader, maxSize uint32) (*RpcResponse, error) {
 	var length uint32
 	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
 		return nil, fmt.Errorf("read length: %w", err)
 	}
-	if length > maxFrameSize {
+	if length > maxSize {
 		return nil, &ProtocolError{Message: fmt.Sprintf("frame too large: %d bytes", length)}
 	}
 	payload := make([]byte, length)
@@ -0,0 +1,196 @@
+package sanctum
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RenewerInput configures a Renewer.
+type RenewerInput struct {
+	// Credential is the lease-bearing credential to keep alive.
+	Credential *Credential
+	// Increment is the renewal increment requested from the server, in
+	// seconds. A value of 0 lets the server choose its own default.
+	Increment int
+	// Grace is the window before TTL expiry in which a lease whose TTL is
+	// more than twice Grace is renewed; shorter leases are renewed at half
+	// their TTL. A failed renewal is retried for up to Grace.
+	// If zero, a default of 10 seconds is used.
+	Grace time.Duration
+}
+
+// RenewOutput is sent on a Renewer's RenewCh after each successful renewal.
+type RenewOutput struct {
+	Credential *Credential
+	RenewedAt  time.Time
+}
+
+// Renewer keeps a leased credential alive by renewing it shortly before its
+// TTL expires, modeled on Vault's lease renewer. Callers typically run it
+// alongside the lifetime of whatever holds the credential:
+//
+//	renewer, err := client.NewRenewer(&sanctum.RenewerInput{Credential: cred})
+//	if err != nil {
+//		return err
+//	}
+//	renewer.Start()
+//	defer renewer.Stop()
+type Renewer struct {
+	client *Client
+	input  RenewerInput
+	grace  time.Duration
+
+	renewCh chan RenewOutput
+	doneCh  chan error
+	quitCh  chan struct{}
+
+	stopOnce sync.Once
+
+	mu   sync.Mutex
+	cred *Credential
+}
+
+// NewRenewer creates a Renewer for the given input. Call Start to begin the
+// renewal loop.
+func (c *Client) NewRenewer(input *RenewerInput) (*Renewer, error) {
+	if input == nil || input.Credential == nil {
+		return nil, fmt.Errorf("sanctum: renewer requires a credential")
+	}
+	if input.Credential.LeaseID == "" {
+		return nil, fmt.Errorf("sanctum: credential has no lease to renew")
+	}
+
+	grace := input.Grace
+	if grace <= 0 {
+		grace = 10 * time.Second
+	}
+
+	return &Renewer{
+		client:  c,
+		input:   *input,
+		grace:   grace,
+		renewCh: make(chan RenewOutput),
+		doneCh:  make(chan error, 1),
+		quitCh:  make(chan struct{}),
+		cred:    input.Credential,
+	}, nil
+}
+
+// Start begins the renewal loop in a background goroutine.
+func (r *Renewer) Start() {
+	go r.run()
+}
+
+// Stop terminates the renewal loop. It is idempotent and safe to call more
+// than once or from multiple goroutines.
+func (r *Renewer) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.quitCh)
+	})
+}
+
+// RenewCh returns the channel on which successfully renewed credentials are
+// delivered.
+func (r *Renewer) RenewCh() <-chan RenewOutput {
+	return r.renewCh
+}
+
+// DoneCh returns a channel that is closed, with an error sent if one caused
+// the renewer to stop, once the renewer can no longer keep the lease alive
+// (a non-renewable lease, a terminal server error, or Stop being called).
+func (r *Renewer) DoneCh() <-chan error {
+	return r.doneCh
+}
+
+func (r *Renewer) run() {
+	defer close(r.doneCh)
+
+	cred := r.currentCredential()
+	for {
+		select {
+		case <-time.After(r.sleepDuration(cred.TTL)):
+		case <-r.quitCh:
+			return
+		}
+
+		renewed, err := r.renewUntilGrace(cred)
+		if err != nil {
+			select {
+			case <-r.quitCh:
+			default:
+				r.doneCh <- err
+			}
+			return
+		}
+
+		cred = renewed
+		r.setCredential(cred)
+
+		select {
+		case r.renewCh <- RenewOutput{Credential: cred, RenewedAt: time.Now()}:
+		case <-r.quitCh:
+			return
+		}
+	}
+}
+
+// renewUntilGrace retries a renewal with exponential backoff until it
+// succeeds or the lease's grace period elapses. A non-recoverable error
+// (a non-renewable lease, a terminal server error) is returned immediately
+// without waiting out the grace period.
+func (r *Renewer) renewUntilGrace(cred *Credential) (*Credential, error) {
+	deadline := time.Now().Add(r.grace)
+	backoff := time.Second
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		renewed, err := r.client.RenewLease(ctx, cred.LeaseID, r.input.Increment)
+		cancel()
+		if err == nil {
+			return renewed, nil
+		}
+		if !IsRecoverable(err) || time.Now().Add(backoff).After(deadline) {
+			return nil, err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-r.quitCh:
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+// sleepDuration computes how long to wait before the next renewal attempt,
+// given the credential's current TTL in seconds: the later of half the TTL
+// plus jitter and TTL minus the grace period. Waiting at least half the TTL
+// means it never renews more than twice per TTL (to avoid hammering the
+// server on short-lived leases). The jitter, applied to the half-TTL wait,
+// avoids a thundering herd of renewals scheduled at the same moment.
+func (r *Renewer) sleepDuration(ttlSeconds uint64) time.Duration {
+	ttl := time.Duration(ttlSeconds) * time.Second
+	jitter := time.Duration(rand.Int63n(int64(ttl/10) + 1))
+
+	half := ttl/2 + jitter
+	untilGrace := ttl - r.grace
+	if half > untilGrace {
+		return half
+	}
+	return untilGrace
+}
+
+func (r *Renewer) currentCredential() *Credential {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cred
+}
+
+func (r *Renewer) setCredential(cred *Credential) {
+	r.mu.Lock()
+	r.cred = cred
+	r.mu.Unlock()
+}
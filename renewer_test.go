@@ -0,0 +1,85 @@
+package sanctum
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestRenewerRenewsThenStops drives a Renewer against a fake lease.renew
+// server over net.Pipe and confirms it delivers a renewed credential on
+// RenewCh, then that Stop ends the loop and closes DoneCh without an error.
+func TestRenewerRenewsThenStops(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go func() {
+		for {
+			req, err := readFrameLimit(serverConn, defaultMaxFrameSize)
+			if err != nil {
+				return
+			}
+			cred := &Credential{Path: "db/creds/app", LeaseID: "lease-1", TTL: 1}
+			result, _ := json.Marshal(cred)
+			if writeTestFrame(serverConn, &RpcResponse{ID: req.ID, Result: result}) != nil {
+				return
+			}
+		}
+	}()
+
+	c := &Client{
+		cfg:     ClientConfig{MaxFrameSize: defaultMaxFrameSize},
+		conn:    clientConn,
+		pending: make(map[uint64]chan callResult),
+		subs:    make(map[string]chan json.RawMessage),
+		closed:  make(chan struct{}),
+	}
+	c.nextID.Store(1)
+	go c.readLoop(clientConn, make(chan error, 1))
+
+	renewer, err := c.NewRenewer(&RenewerInput{
+		Credential: &Credential{Path: "db/creds/app", LeaseID: "lease-1", TTL: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewRenewer: %v", err)
+	}
+	renewer.Start()
+
+	select {
+	case out := <-renewer.RenewCh():
+		if out.Credential.LeaseID != "lease-1" {
+			t.Fatalf("got lease %q, want lease-1", out.Credential.LeaseID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("renewer never delivered a renewal")
+	}
+
+	renewer.Stop()
+	renewer.Stop() // idempotent
+
+	select {
+	case err, ok := <-renewer.DoneCh():
+		if ok && err != nil {
+			t.Fatalf("DoneCh sent error after Stop: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("DoneCh never closed after Stop")
+	}
+}
+
+// TestNewRenewerRequiresLease confirms NewRenewer rejects credentials that
+// have nothing to renew, before ever starting the background goroutine.
+func TestNewRenewerRequiresLease(t *testing.T) {
+	c := &Client{}
+
+	if _, err := c.NewRenewer(nil); err == nil {
+		t.Fatal("expected error for nil input")
+	}
+	if _, err := c.NewRenewer(&RenewerInput{}); err == nil {
+		t.Fatal("expected error for missing credential")
+	}
+	if _, err := c.NewRenewer(&RenewerInput{Credential: &Credential{}}); err == nil {
+		t.Fatal("expected error for credential with no lease id")
+	}
+}
@@ -1,6 +1,9 @@
 package sanctum
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // RpcRequest represents a JSON-RPC request.
 type RpcRequest struct {
@@ -9,11 +12,38 @@ type RpcRequest struct {
 	Params json.RawMessage `json:"params"`
 }
 
-// RpcResponse represents a JSON-RPC response.
+// RpcResponse represents a frame read from the server: either a reply to
+// a request (ID matching the request's ID) or a server-pushed
+// Notification (ID == 0, Method set) such as an audit.subscribe event.
 type RpcResponse struct {
 	ID     uint64          `json:"id"`
 	Result json.RawMessage `json:"result,omitempty"`
 	Error  json.RawMessage `json:"error,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// AuditFilter narrows the events streamed by Client.TailAudit /
+// Vault.TailAudit. Since is a pointer so that leaving it unset omits the
+// field entirely rather than serializing a zero-value timestamp: time.Time
+// isn't one of the types json's omitempty treats as "empty".
+type AuditFilter struct {
+	AgentID     string     `json:"agent_id,omitempty"`
+	Path        string     `json:"path,omitempty"`
+	Action      string     `json:"action,omitempty"`
+	Since       *time.Time `json:"since,omitempty"`
+	MinSeverity string     `json:"min_severity,omitempty"`
+}
+
+// AuditEvent is a single audit entry streamed by TailAudit.
+type AuditEvent struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Agent     string          `json:"agent"`
+	Action    string          `json:"action"`
+	Path      string          `json:"path"`
+	Result    string          `json:"result"`
+	LeaseID   string          `json:"lease_id,omitempty"`
+	Detail    json.RawMessage `json:"detail,omitempty"`
 }
 
 // Credential holds a retrieved credential with lease info.
@@ -47,3 +77,13 @@ type authResult struct {
 	Authenticated bool   `json:"authenticated"`
 	SessionID     string `json:"session_id,omitempty"`
 }
+
+// WrappedToken is a single-use token created by Client.Wrap. It can be
+// handed off to another agent or subprocess, which exchanges it exactly
+// once for the underlying credential via Client.Unwrap.
+type WrappedToken struct {
+	Token        string    `json:"token"`
+	CreationPath string    `json:"creation_path"`
+	CreationTime time.Time `json:"creation_time"`
+	TTL          uint64    `json:"ttl"`
+}
@@ -9,7 +9,9 @@ package sanctum
 */
 import "C"
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"unsafe"
 )
 
@@ -145,6 +147,138 @@ func (v *Vault) CheckPolicy(name string, agentID string) error {
 	return resultToError(rc)
 }
 
+// Wrap creates a single-use wrapped token for the named credential, valid
+// for ttl seconds, that a sibling agent or subprocess can exchange exactly
+// once via Unwrap. agentID identifies the creating agent for the audit
+// log.
+func (v *Vault) Wrap(name, agentID string, ttl uint64) (string, error) {
+	if v.ptr == nil {
+		return "", errors.New("sanctum: vault is closed")
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cAgent := C.CString(agentID)
+	defer C.free(unsafe.Pointer(cAgent))
+
+	// First call to get required size.
+	var needed C.uintptr_t
+	rc := C.sanctum_vault_wrap(v.ptr, cName, cAgent, C.uint64_t(ttl), nil, &needed)
+	if rc != C.BUFFER_TOO_SMALL && rc != C.OK {
+		return "", resultToError(rc)
+	}
+	if needed == 0 {
+		return "", errors.New("sanctum: wrap returned no token")
+	}
+
+	buf := make([]byte, int(needed))
+	outLen := needed
+	rc = C.sanctum_vault_wrap(
+		v.ptr, cName, cAgent, C.uint64_t(ttl),
+		(*C.uint8_t)(unsafe.Pointer(&buf[0])),
+		&outLen,
+	)
+	if err := resultToError(rc); err != nil {
+		return "", err
+	}
+	return string(buf[:int(outLen)]), nil
+}
+
+// Unwrap exchanges a wrapped token for the secret it holds, returning the
+// secret bytes and the credential path it was wrapped from. agentID
+// identifies the unwrapping agent for the audit log. Tokens are
+// single-use: the vault deletes the mapping on the first successful
+// unwrap, or once it expires.
+func (v *Vault) Unwrap(token, agentID string) ([]byte, string, error) {
+	if v.ptr == nil {
+		return nil, "", errors.New("sanctum: vault is closed")
+	}
+
+	cToken := C.CString(token)
+	defer C.free(unsafe.Pointer(cToken))
+	cAgent := C.CString(agentID)
+	defer C.free(unsafe.Pointer(cAgent))
+
+	const maxPath = 4096
+	pathBuf := make([]byte, maxPath)
+	pathLen := C.uintptr_t(maxPath)
+
+	// First call to get required secret size; the path is small and
+	// fixed-capacity so it comes back in the same call.
+	var needed C.uintptr_t
+	rc := C.sanctum_vault_unwrap(
+		v.ptr, cToken, cAgent,
+		nil, &needed,
+		(*C.char)(unsafe.Pointer(&pathBuf[0])), &pathLen,
+	)
+	if rc != C.BUFFER_TOO_SMALL && rc != C.OK {
+		return nil, "", resultToError(rc)
+	}
+	if needed == 0 {
+		return []byte{}, string(pathBuf[:int(pathLen)]), nil
+	}
+
+	secret := make([]byte, int(needed))
+	outLen := needed
+	rc = C.sanctum_vault_unwrap(
+		v.ptr, cToken, cAgent,
+		(*C.uint8_t)(unsafe.Pointer(&secret[0])), &outLen,
+		(*C.char)(unsafe.Pointer(&pathBuf[0])), &pathLen,
+	)
+	if err := resultToError(rc); err != nil {
+		return nil, "", err
+	}
+	return secret[:int(outLen)], string(pathBuf[:int(pathLen)]), nil
+}
+
+// Capabilities returns the set of allowed actions (e.g. "retrieve", "use",
+// "delete", "list", "wrap", "renew") per path for agentID, evaluated
+// against each path's stored policy. It reuses AuditLog's two-call
+// buffer-sizing pattern, since the result size depends on how many paths
+// and policies match.
+func (v *Vault) Capabilities(agentID string, paths []string) (map[string][]string, error) {
+	if v.ptr == nil {
+		return nil, errors.New("sanctum: vault is closed")
+	}
+
+	pathsJSON, err := json.Marshal(paths)
+	if err != nil {
+		return nil, fmt.Errorf("sanctum: marshal paths: %w", err)
+	}
+
+	cAgent := C.CString(agentID)
+	defer C.free(unsafe.Pointer(cAgent))
+	cPaths := C.CString(string(pathsJSON))
+	defer C.free(unsafe.Pointer(cPaths))
+
+	// First call to get required size.
+	var needed C.uintptr_t
+	rc := C.sanctum_vault_capabilities(v.ptr, cAgent, cPaths, nil, &needed)
+	if rc != C.BUFFER_TOO_SMALL && rc != C.OK {
+		return nil, resultToError(rc)
+	}
+	if needed == 0 {
+		return map[string][]string{}, nil
+	}
+
+	buf := make([]byte, int(needed)+1) // +1 for NUL
+	outLen := C.uintptr_t(len(buf))
+	rc = C.sanctum_vault_capabilities(
+		v.ptr, cAgent, cPaths,
+		(*C.uint8_t)(unsafe.Pointer(&buf[0])),
+		&outLen,
+	)
+	if err := resultToError(rc); err != nil {
+		return nil, err
+	}
+
+	var caps map[string][]string
+	if err := json.Unmarshal(buf[:int(outLen)], &caps); err != nil {
+		return nil, fmt.Errorf("sanctum: parse capabilities: %w", err)
+	}
+	return caps, nil
+}
+
 // AuditLog returns the audit log as a JSON string.
 // If agentIDFilter is non-empty, only entries for that agent are returned.
 func (v *Vault) AuditLog(agentIDFilter string) (string, error) {
@@ -178,6 +178,77 @@ func TestListCredentials(t *testing.T) {
 	}
 }
 
+func TestWrapAndUnwrap(t *testing.T) {
+	v := tempVault(t, []byte("pass"))
+
+	secret := []byte("super-secret-value")
+	if err := v.Store("db/creds/app", secret, "agent-1", ""); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	token, err := v.Wrap("db/creds/app", "agent-1", 60)
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	if token == "" {
+		t.Fatal("Wrap returned an empty token")
+	}
+
+	got, path, err := v.Unwrap(token, "agent-2")
+	if err != nil {
+		t.Fatalf("Unwrap: %v", err)
+	}
+	if string(got) != string(secret) {
+		t.Fatalf("got %q, want %q", got, secret)
+	}
+	if path != "db/creds/app" {
+		t.Fatalf("got creation path %q, want db/creds/app", path)
+	}
+
+	// The token is single-use: a second unwrap must fail.
+	if _, _, err := v.Unwrap(token, "agent-2"); err == nil {
+		t.Fatal("expected error unwrapping an already-unwrapped token")
+	}
+}
+
+func TestUnwrapUnknownToken(t *testing.T) {
+	v := tempVault(t, []byte("pass"))
+
+	_, _, err := v.Unwrap("not-a-real-token", "agent-1")
+	if err == nil {
+		t.Fatal("expected error unwrapping an unknown token")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	v := tempVault(t, []byte("pass"))
+
+	policy := `{"name":"test","principal":"agent:agent-1","resources":["restricted"],"actions":["retrieve","renew"],"max_lease_ttl":3600,"conditions":{},"enabled":true}`
+	if err := v.Store("restricted", []byte("secret"), "agent-1", policy); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	caps, err := v.Capabilities("agent-1", []string{"restricted"})
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	actions, ok := caps["restricted"]
+	if !ok {
+		t.Fatalf("capabilities missing entry for restricted: %v", caps)
+	}
+	if !strings.Contains(strings.Join(actions, ","), "retrieve") {
+		t.Fatalf("capabilities for agent-1 missing retrieve: %v", actions)
+	}
+
+	caps, err = v.Capabilities("agent-2", []string{"restricted"})
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if len(caps["restricted"]) != 0 {
+		t.Fatalf("expected no capabilities for agent-2, got: %v", caps["restricted"])
+	}
+}
+
 func TestDeleteNotFound(t *testing.T) {
 	v := tempVault(t, []byte("pass"))
 